@@ -0,0 +1,6 @@
+// Package migrations gives access to the SQL migrations for the
+// multiaccounts database. The migration files live under sql/ and are
+// embedded into the binary at build time, so they can be read and edited
+// like any other source file instead of being regenerated by a separate
+// code-generation step.
+package migrations
@@ -0,0 +1,215 @@
+package migrations
+
+import (
+	"crypto/sha256"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"sync"
+	"time"
+)
+
+//go:embed sql/*.sql
+var assets embed.FS
+
+const assetsDir = "sql"
+
+// modTimes holds the modification time reported for each migration file. It
+// is a hand-maintained table, not generated at build time: files that are
+// not listed here (for example ones added without updating it) simply
+// report a zero time.
+var modTimes = map[string]time.Time{
+	"0001_accounts.down.sql": time.Unix(1574771268, 0),
+	"0001_accounts.up.sql":   time.Unix(1580303056, 0),
+}
+
+// asset caches the decoded bytes and the digest of a migration file so
+// repeated calls to Asset/AssetDigest don't re-read or re-hash it.
+type asset struct {
+	bytes  []byte
+	digest [sha256.Size]byte
+}
+
+var (
+	cacheMu sync.Mutex
+	cache   = map[string]*asset{}
+)
+
+func load(name string) (*asset, error) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	if a, ok := cache[name]; ok {
+		return a, nil
+	}
+
+	data, err := fs.ReadFile(assets, path.Join(assetsDir, name))
+	if err != nil {
+		return nil, fmt.Errorf("asset %s not found: %v", name, err)
+	}
+
+	a := &asset{bytes: data, digest: sha256.Sum256(data)}
+	cache[name] = a
+	return a, nil
+}
+
+// assetFileInfo is a minimal os.FileInfo implementation for an embedded
+// migration, kept for API compatibility with code that inspects AssetInfo.
+type assetFileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+func (fi assetFileInfo) Name() string       { return fi.name }
+func (fi assetFileInfo) Size() int64        { return fi.size }
+func (fi assetFileInfo) Mode() os.FileMode  { return 0644 }
+func (fi assetFileInfo) ModTime() time.Time { return fi.modTime }
+func (fi assetFileInfo) IsDir() bool        { return false }
+func (fi assetFileInfo) Sys() interface{}   { return nil }
+
+// Asset loads and returns the asset for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func Asset(name string) ([]byte, error) {
+	a, err := load(name)
+	if err != nil {
+		return nil, err
+	}
+	return a.bytes, nil
+}
+
+// AssetString returns the asset contents as a string (instead of a []byte).
+func AssetString(name string) (string, error) {
+	data, err := Asset(name)
+	return string(data), err
+}
+
+// MustAsset is like Asset but panics when Asset would return an error.
+// It simplifies safe initialization of global variables.
+func MustAsset(name string) []byte {
+	data, err := Asset(name)
+	if err != nil {
+		panic("asset: Asset(" + name + "): " + err.Error())
+	}
+	return data
+}
+
+// MustAssetString is like AssetString but panics when Asset would return an
+// error. It simplifies safe initialization of global variables.
+func MustAssetString(name string) string {
+	return string(MustAsset(name))
+}
+
+// AssetInfo loads and returns the asset info for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func AssetInfo(name string) (os.FileInfo, error) {
+	a, err := load(name)
+	if err != nil {
+		return nil, fmt.Errorf("AssetInfo %s can't read by error: %v", name, err)
+	}
+	return assetFileInfo{name: name, size: int64(len(a.bytes)), modTime: modTimes[name]}, nil
+}
+
+// AssetDigest returns the digest of the file with the given name. It returns an
+// error if the asset could not be found or the digest could not be loaded.
+func AssetDigest(name string) ([sha256.Size]byte, error) {
+	a, err := load(name)
+	if err != nil {
+		return [sha256.Size]byte{}, fmt.Errorf("AssetDigest %s can't read by error: %v", name, err)
+	}
+	return a.digest, nil
+}
+
+// Digests returns a map of all known files and their checksums.
+func Digests() (map[string][sha256.Size]byte, error) {
+	names, err := assetNames()
+	if err != nil {
+		return nil, err
+	}
+	mp := make(map[string][sha256.Size]byte, len(names))
+	for _, name := range names {
+		a, err := load(name)
+		if err != nil {
+			return nil, err
+		}
+		mp[name] = a.digest
+	}
+	return mp, nil
+}
+
+// AssetNames returns the names of the assets.
+func AssetNames() []string {
+	names, err := assetNames()
+	if err != nil {
+		// sql/*.sql is embedded at build time, so a failure here means the
+		// binary was built without the migrations - there is nothing a
+		// caller can recover from.
+		panic("migrations: " + err.Error())
+	}
+	return names
+}
+
+func assetNames() ([]string, error) {
+	entries, err := fs.ReadDir(assets, assetsDir)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// AssetDir returns the file names below a certain directory. Migrations are
+// stored as a flat list, so AssetDir("") returns every asset name and
+// AssetDir of anything else returns an error, matching the go-bindata
+// behaviour this package replaces.
+func AssetDir(name string) ([]string, error) {
+	if name != "" {
+		return nil, fmt.Errorf("Asset %s not found", name)
+	}
+	return assetNames()
+}
+
+// RestoreAsset restores an asset under the given directory.
+func RestoreAsset(dir, name string) error {
+	data, err := Asset(name)
+	if err != nil {
+		return err
+	}
+	info, err := AssetInfo(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	target := path.Join(dir, name)
+	if err := os.WriteFile(target, data, info.Mode()); err != nil {
+		return err
+	}
+	return os.Chtimes(target, info.ModTime(), info.ModTime())
+}
+
+// RestoreAssets restores an asset under the given directory recursively.
+func RestoreAssets(dir, name string) error {
+	children, err := AssetDir(name)
+	// File
+	if err != nil {
+		return RestoreAsset(dir, name)
+	}
+	// Dir
+	for _, child := range children {
+		if err := RestoreAssets(dir, child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
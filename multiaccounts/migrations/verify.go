@@ -0,0 +1,286 @@
+package migrations
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// schemaMigrationsTable is the table the migration runner (golang-migrate
+// and its status-go fork) uses to track the currently applied version. It
+// only ever holds the single most recent version - SetVersion deletes and
+// re-inserts it on every migration - so it cannot be used to look up the
+// digest of any migration other than the latest one.
+const schemaMigrationsTable = "schema_migrations"
+
+// digestTable is a table this package owns outright, used to keep a
+// per-migration history of applied digests that schemaMigrationsTable
+// itself cannot provide.
+const digestTable = "migrations_digest"
+
+// digestStateTable records whether the one-shot backfill in backfillDigests
+// has already run, so it only ever seeds a version's digest from currently
+// embedded content once - a version applied afterwards with no digest row
+// must be flagged by Verify rather than silently backfilled, or tampering
+// with its file would never be detected.
+const digestStateTable = "migrations_digest_state"
+
+// DigestMismatchError is returned by Verify when an already-applied
+// migration's embedded content no longer matches the digest that was
+// recorded when it was applied, i.e. the migration file was edited after
+// the fact.
+type DigestMismatchError struct {
+	Name    string
+	Stored  string
+	Current string
+}
+
+func (e *DigestMismatchError) Error() string {
+	return fmt.Sprintf("migration %s has been modified since it was applied: stored digest %s, current digest %s", e.Name, e.Stored, e.Current)
+}
+
+// UnverifiedMigrationError is returned by Verify when schema_migrations
+// reports a migration as applied but it has no row in digestTable, i.e.
+// RecordDigest was never called for it. Verify has no baseline to check
+// such a migration against, so it is surfaced as an error instead of being
+// silently skipped.
+type UnverifiedMigrationError struct {
+	Name string
+}
+
+func (e *UnverifiedMigrationError) Error() string {
+	return fmt.Sprintf("migration %s was applied but has no recorded digest: RecordDigest was never called for it", e.Name)
+}
+
+var versionPrefix = regexp.MustCompile(`^0*(\d+)_`)
+
+// assetVersion extracts the numeric migration version from an asset name,
+// e.g. "0001_accounts.up.sql" -> 1.
+func assetVersion(name string) (uint64, bool) {
+	m := versionPrefix.FindStringSubmatch(name)
+	if m == nil {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(m[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+// assetsByVersion builds a lookup of migration version to the name of its
+// .up.sql asset, which is what Verify needs to compare against.
+func assetsByVersion() (map[uint64]string, error) {
+	names, err := assetNames()
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[uint64]string, len(names))
+	for _, name := range names {
+		if !strings.HasSuffix(name, ".up.sql") {
+			continue
+		}
+		v, ok := assetVersion(name)
+		if !ok {
+			continue
+		}
+		result[v] = name
+	}
+	return result, nil
+}
+
+// ensureDigestTable creates the table this package uses to keep a
+// per-migration digest history, if it doesn't exist yet.
+func ensureDigestTable(db *sql.DB) error {
+	_, err := db.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (version INTEGER PRIMARY KEY, digest TEXT NOT NULL)`, digestTable))
+	return err
+}
+
+// ensureDigestTables creates both digestTable and digestStateTable, if they
+// don't exist yet.
+func ensureDigestTables(db *sql.DB) error {
+	if err := ensureDigestTable(db); err != nil {
+		return err
+	}
+	_, err := db.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (id INTEGER PRIMARY KEY CHECK (id = 1), backfilled INTEGER NOT NULL)`, digestStateTable))
+	return err
+}
+
+// currentVersion reads the version schemaMigrationsTable currently records
+// as applied. It returns 0, false if no migration has been applied yet.
+func currentVersion(db *sql.DB) (uint64, bool, error) {
+	var version sql.NullInt64
+	err := db.QueryRow(fmt.Sprintf(`SELECT MAX(version) FROM %s`, schemaMigrationsTable)).Scan(&version)
+	if err != nil {
+		return 0, false, err
+	}
+	if !version.Valid {
+		return 0, false, nil
+	}
+	return uint64(version.Int64), true, nil
+}
+
+func backfillDone(db *sql.DB) (bool, error) {
+	var backfilled int
+	err := db.QueryRow(fmt.Sprintf(`SELECT backfilled FROM %s WHERE id = 1`, digestStateTable)).Scan(&backfilled)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return backfilled != 0, nil
+}
+
+func markBackfillDone(db *sql.DB) error {
+	_, err := db.Exec(fmt.Sprintf(`INSERT INTO %s (id, backfilled) VALUES (1, 1) ON CONFLICT(id) DO UPDATE SET backfilled = 1`, digestStateTable))
+	return err
+}
+
+// backfillDigests performs a one-shot backfill for databases that were
+// upgraded across the release that introduced digestTable: every migration
+// up to and including the version schema_migrations currently records is
+// assumed, in the absence of any prior record, to match its currently
+// embedded content, and is recorded as a baseline to detect tampering from
+// this point on. It is a no-op on every call after the first.
+//
+// This relies on migrations being embedded and applied in strict,
+// contiguous numeric order, which is how this package names and applies
+// them; it cannot recover the digest actually in effect at the time an
+// older migration ran. Any migration applied after this one-shot backfill
+// runs is expected to have been recorded by RecordDigest instead; Verify
+// treats one that wasn't as an error rather than backfilling it again.
+func backfillDigests(db *sql.DB) error {
+	if err := ensureDigestTables(db); err != nil {
+		return err
+	}
+
+	done, err := backfillDone(db)
+	if err != nil {
+		return err
+	}
+	if done {
+		return nil
+	}
+
+	version, ok, err := currentVersion(db)
+	if err != nil {
+		return err
+	}
+	if ok {
+		versions, err := assetsByVersion()
+		if err != nil {
+			return err
+		}
+		for v, name := range versions {
+			if v > version {
+				continue
+			}
+			digest, err := AssetDigest(name)
+			if err != nil {
+				return err
+			}
+			query := fmt.Sprintf(`INSERT INTO %s (version, digest) VALUES (?, ?) ON CONFLICT(version) DO NOTHING`, digestTable)
+			if _, err := db.Exec(query, v, hex.EncodeToString(digest[:])); err != nil {
+				return fmt.Errorf("failed to backfill digest for version %d: %v", v, err)
+			}
+		}
+	}
+	return markBackfillDone(db)
+}
+
+// RecordDigest persists the digest of the migration asset identified by
+// name under version in the digest history. It must be called by the
+// migration runner right after that migration has been applied, so that
+// Verify can later detect if its file was edited afterwards.
+func RecordDigest(db *sql.DB, version uint64, name string) error {
+	if err := ensureDigestTable(db); err != nil {
+		return err
+	}
+	digest, err := AssetDigest(name)
+	if err != nil {
+		return err
+	}
+	query := fmt.Sprintf(`INSERT INTO %s (version, digest) VALUES (?, ?) ON CONFLICT(version) DO UPDATE SET digest = excluded.digest`, digestTable)
+	_, err = db.Exec(query, version, hex.EncodeToString(digest[:]))
+	return err
+}
+
+// Verify compares the digest recorded for every applied migration against
+// the digest of its currently embedded content. It returns
+// a *DigestMismatchError naming the first migration whose file has been
+// edited since it was applied - a common source of silent corruption for
+// databases upgraded across releases - or an *UnverifiedMigrationError if
+// an applied migration has no recorded digest at all.
+func Verify(db *sql.DB) error {
+	if err := backfillDigests(db); err != nil {
+		return err
+	}
+
+	version, ok, err := currentVersion(db)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+
+	versions, err := assetsByVersion()
+	if err != nil {
+		return err
+	}
+
+	recorded := make(map[uint64]string, len(versions))
+	rows, err := db.Query(fmt.Sprintf(`SELECT version, digest FROM %s`, digestTable))
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var (
+			v uint64
+			d string
+		)
+		if err := rows.Scan(&v, &d); err != nil {
+			rows.Close()
+			return err
+		}
+		recorded[v] = d
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	rows.Close()
+
+	applied := make([]uint64, 0, len(versions))
+	for v := range versions {
+		if v <= version {
+			applied = append(applied, v)
+		}
+	}
+	sort.Slice(applied, func(i, j int) bool { return applied[i] < applied[j] })
+
+	for _, v := range applied {
+		name := versions[v]
+
+		stored, ok := recorded[v]
+		if !ok {
+			return &UnverifiedMigrationError{Name: name}
+		}
+
+		digest, err := AssetDigest(name)
+		if err != nil {
+			return err
+		}
+		current := hex.EncodeToString(digest[:])
+
+		if stored != current {
+			return &DigestMismatchError{Name: name, Stored: stored, Current: current}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package migrations
+
+import (
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// setupVerifyDB mimics the schema golang-migrate (and its status-go fork)
+// maintains: a single row holding the currently applied version.
+func setupVerifyDB(t *testing.T, version uint64) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`CREATE TABLE schema_migrations (version INTEGER PRIMARY KEY, dirty BOOL NOT NULL)`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`INSERT INTO schema_migrations(version, dirty) VALUES (?, false)`, version); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestVerifyBackfillsAndPasses(t *testing.T) {
+	db := setupVerifyDB(t, 1)
+	if err := Verify(db); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestVerifyDetectsTamper(t *testing.T) {
+	db := setupVerifyDB(t, 1)
+	if err := backfillDigests(db); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`UPDATE migrations_digest SET digest = 'deadbeef' WHERE version = 1`); err != nil {
+		t.Fatal(err)
+	}
+
+	err := Verify(db)
+	if err == nil {
+		t.Fatal("expected a digest mismatch error")
+	}
+	if _, ok := err.(*DigestMismatchError); !ok {
+		t.Fatalf("expected *DigestMismatchError, got %T: %v", err, err)
+	}
+}
+
+func TestRecordDigest(t *testing.T) {
+	db := setupVerifyDB(t, 1)
+	if err := RecordDigest(db, 1, "0001_accounts.up.sql"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify(db); err != nil {
+		t.Fatalf("expected no error after recording digest, got %v", err)
+	}
+}
+
+// TestVerifyRetainsHistoryPastSchemaMigrationsCurrentRow makes sure Verify
+// relies on this package's own digest history rather than on
+// schema_migrations for anything beyond the current version: golang-migrate
+// overwrites that table's single row on every migration, so a digest
+// recorded for an older version must still be there, and still checked,
+// after schema_migrations has moved on.
+func TestVerifyRetainsHistoryPastSchemaMigrationsCurrentRow(t *testing.T) {
+	db := setupVerifyDB(t, 1)
+	if err := RecordDigest(db, 1, "0001_accounts.up.sql"); err != nil {
+		t.Fatal(err)
+	}
+
+	// schema_migrations moves on to a later version, as golang-migrate does
+	// on every applied migration, overwriting its one row.
+	if _, err := db.Exec(`UPDATE schema_migrations SET version = 2 WHERE version = 1`); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.Exec(`UPDATE migrations_digest SET digest = 'deadbeef' WHERE version = 1`); err != nil {
+		t.Fatal(err)
+	}
+
+	err := Verify(db)
+	if err == nil {
+		t.Fatal("expected a digest mismatch error for the earlier migration")
+	}
+	mismatch, ok := err.(*DigestMismatchError)
+	if !ok {
+		t.Fatalf("expected *DigestMismatchError, got %T: %v", err, err)
+	}
+	if mismatch.Name != "0001_accounts.up.sql" {
+		t.Fatalf("expected the mismatch to name 0001_accounts.up.sql, got %s", mismatch.Name)
+	}
+}
+
+// TestVerifyFlagsMigrationAppliedWithoutRecordedDigest makes sure a
+// migration that schema_migrations reports as applied, but that has no row
+// in the digest history, is surfaced as an error rather than silently
+// skipped - the backfill only ever seeds a missing digest once, so this is
+// what happens to any migration applied without a matching RecordDigest
+// call once that one-shot backfill has already run.
+func TestVerifyFlagsMigrationAppliedWithoutRecordedDigest(t *testing.T) {
+	db := setupVerifyDB(t, 1)
+
+	// The first Verify call performs the one-shot backfill and marks it
+	// done.
+	if err := Verify(db); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Simulate a migration that was applied without RecordDigest ever being
+	// called for it.
+	if _, err := db.Exec(`DELETE FROM migrations_digest WHERE version = 1`); err != nil {
+		t.Fatal(err)
+	}
+
+	err := Verify(db)
+	if err == nil {
+		t.Fatal("expected an unverified migration error")
+	}
+	unverified, ok := err.(*UnverifiedMigrationError)
+	if !ok {
+		t.Fatalf("expected *UnverifiedMigrationError, got %T: %v", err, err)
+	}
+	if unverified.Name != "0001_accounts.up.sql" {
+		t.Fatalf("expected the error to name 0001_accounts.up.sql, got %s", unverified.Name)
+	}
+}
+
+// TestBackfillDigestsIsOneShot makes sure the backfill doesn't keep
+// re-seeding a digest from currently embedded content on every call - doing
+// so would mean a migration tampered with after being applied, but before
+// RecordDigest got a chance to run for it, would simply have the tampered
+// content adopted as its baseline instead of being flagged.
+func TestBackfillDigestsIsOneShot(t *testing.T) {
+	db := setupVerifyDB(t, 1)
+	if err := backfillDigests(db); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`DELETE FROM migrations_digest WHERE version = 1`); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := backfillDigests(db); err != nil {
+		t.Fatal(err)
+	}
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM migrations_digest WHERE version = 1`).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Fatalf("expected the backfill to be a no-op on the second call, got %d rows", count)
+	}
+}
@@ -1,6 +1,8 @@
 package waku
 
 import (
+	"bytes"
+	"compress/gzip"
 	"errors"
 	"fmt"
 	"io"
@@ -24,8 +26,44 @@ type statusOptions struct {
 	ConfirmationsEnabled *bool       `rlp:"key=3"`
 	RateLimits           *RateLimits `rlp:"key=4"`
 	TopicInterest        []TopicType `rlp:"key=5"`
+	// Version is the protocol version this peer speaks. It is absent for
+	// peers that predate version negotiation, which are treated as version 0.
+	Version *uint64 `rlp:"key=6"`
+	// Capabilities lists the optional protocol features this peer is
+	// willing to use, e.g. alternative wire encodings. A peer only relies
+	// on a capability once it has been negotiated with Negotiate.
+	Capabilities []string `rlp:"key=7"`
+	// CompressedTopicInterest is a gzip-compressed RLP-encoded
+	// []TopicType, used instead of TopicInterest once
+	// CapabilityCompressedTopicInterest has been negotiated, so that the
+	// plain-list item cap in Validate does not apply.
+	CompressedTopicInterest []byte `rlp:"key=8"`
 }
 
+// baseProtocolVersion is the version assumed for a peer that does not
+// advertise an explicit Version, keeping old peers compatible with the
+// forward-compatible RLP decoder.
+const baseProtocolVersion = uint64(0)
+
+// CapabilityCompressedTopicInterest is advertised by a peer that
+// understands CompressedTopicInterest and will accept a topic interest
+// larger than maxPlainTopicInterest when it is sent that way.
+const CapabilityCompressedTopicInterest = "topic-interest/compressed"
+
+// maxPlainTopicInterest is the largest TopicInterest list Validate accepts
+// when CompressedTopicInterest is not used.
+const maxPlainTopicInterest = 1000
+
+// maxTopicInterest bounds the number of topics decoded out of a
+// CompressedTopicInterest payload, so a peer can't use compression to
+// smuggle in an unbounded list.
+const maxTopicInterest = 100000
+
+// maxCompressedTopicInterestBytes bounds how much decompressed data
+// decompressTopicInterest will read from a CompressedTopicInterest payload,
+// guarding against a decompression bomb.
+const maxCompressedTopicInterestBytes = 10 * 1024 * 1024
+
 func (s statusOptions) WithoutDefaults() statusOptions {
 	if s.PoWRequirement != nil && *s.PoWRequirement == 0 {
 		s.PoWRequirement = nil
@@ -46,6 +84,71 @@ func (s statusOptions) WithoutDefaults() statusOptions {
 	return s
 }
 
+// version returns the protocol version s advertises, or baseProtocolVersion
+// if it didn't advertise one.
+func (s statusOptions) version() uint64 {
+	if s.Version == nil {
+		return baseProtocolVersion
+	}
+	return *s.Version
+}
+
+// Negotiate computes the statusOptions that should govern a session with
+// remote: the minimum of the two advertised protocol versions, and the
+// intersection of their advertised capabilities. It should be called on the
+// options received from a remote peer before WithDefaults is applied, so
+// that features gated behind a capability are only used once both sides
+// have agreed to support them.
+func (s statusOptions) Negotiate(remote statusOptions) (statusOptions, error) {
+	negotiated := s
+
+	version := s.version()
+	if remoteVersion := remote.version(); remoteVersion < version {
+		version = remoteVersion
+	}
+	negotiated.Version = &version
+	negotiated.Capabilities = intersectCapabilities(s.Capabilities, remote.Capabilities)
+
+	return negotiated, nil
+}
+
+// NegotiateHandshake is the entry point the handshake code must call on a
+// peer's freshly decoded statusOptions instead of calling
+// Validate/WithDefaults on it directly. It negotiates remote against the
+// local node's own options first, so that capability-gated behaviour (such
+// as CompressedTopicInterest) is only trusted once both peers have actually
+// agreed to support it, validates the negotiated result, and only then
+// applies WithDefaults.
+func (local statusOptions) NegotiateHandshake(remote statusOptions) (statusOptions, error) {
+	negotiated, err := remote.Negotiate(local)
+	if err != nil {
+		return statusOptions{}, err
+	}
+	if err := negotiated.Validate(); err != nil {
+		return statusOptions{}, err
+	}
+	return negotiated.WithDefaults(), nil
+}
+
+func intersectCapabilities(a, b []string) []string {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+
+	has := make(map[string]bool, len(b))
+	for _, c := range b {
+		has[c] = true
+	}
+
+	var result []string
+	for _, c := range a {
+		if has[c] {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
 func (s statusOptions) WithDefaults() statusOptions {
 	if s.PoWRequirement == nil {
 		var pow uint64 = 0
@@ -174,9 +277,90 @@ loop:
 	return s.ListEnd()
 }
 
+// Validate checks o against the protocol's limits. It must be called on a
+// statusOptions that has already been through Negotiate (e.g. via
+// NegotiateHandshake) so that o.Capabilities reflects what both peers
+// actually agreed to support, not merely what the remote peer claims: a
+// peer that unilaterally sets CompressedTopicInterest without the
+// capability having been negotiated is rejected rather than allowed to
+// bypass the plain-list cap.
 func (o statusOptions) Validate() error {
-	if len(o.TopicInterest) > 1000 {
+	if len(o.CompressedTopicInterest) > 0 {
+		if !hasCapability(o.Capabilities, CapabilityCompressedTopicInterest) {
+			return errors.New("compressed topic interest was used without negotiating its capability")
+		}
+		return nil
+	}
+	if len(o.TopicInterest) > maxPlainTopicInterest {
 		return errors.New("topic interest is limited by 1000 items")
 	}
 	return nil
 }
+
+// EncodeTopicInterest picks the most compact wire representation for
+// topics given the capabilities the remote peer has advertised: a
+// gzip-compressed blob routed through CompressedTopicInterest once
+// CapabilityCompressedTopicInterest has been negotiated and the list is
+// past the plain cap, or the plain TopicInterest list otherwise. The
+// returned statusOptions should have its other fields merged in by the
+// caller.
+func EncodeTopicInterest(topics []TopicType, remoteCapabilities []string) (statusOptions, error) {
+	if len(topics) <= maxPlainTopicInterest || !hasCapability(remoteCapabilities, CapabilityCompressedTopicInterest) {
+		return statusOptions{TopicInterest: topics}, nil
+	}
+
+	compressed, err := compressTopicInterest(topics)
+	if err != nil {
+		return statusOptions{}, err
+	}
+	return statusOptions{CompressedTopicInterest: compressed}, nil
+}
+
+// DecodeTopicInterest returns the effective topic interest advertised by o,
+// transparently decompressing CompressedTopicInterest when it was used
+// instead of TopicInterest.
+func DecodeTopicInterest(o statusOptions) ([]TopicType, error) {
+	if len(o.CompressedTopicInterest) == 0 {
+		return o.TopicInterest, nil
+	}
+	return decompressTopicInterest(o.CompressedTopicInterest)
+}
+
+func hasCapability(capabilities []string, capability string) bool {
+	for _, c := range capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+func compressTopicInterest(topics []TopicType) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := rlp.Encode(gz, topics); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompressTopicInterest(data []byte) ([]TopicType, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid compressed topic interest: %w", err)
+	}
+	defer gz.Close()
+
+	var topics []TopicType
+	stream := rlp.NewStream(io.LimitReader(gz, maxCompressedTopicInterestBytes), maxCompressedTopicInterestBytes)
+	if err := stream.Decode(&topics); err != nil {
+		return nil, fmt.Errorf("invalid compressed topic interest: %w", err)
+	}
+	if len(topics) > maxTopicInterest {
+		return nil, fmt.Errorf("compressed topic interest exceeds %d items", maxTopicInterest)
+	}
+	return topics, nil
+}
@@ -0,0 +1,67 @@
+package waku
+
+import "testing"
+
+func uint64p(v uint64) *uint64 { return &v }
+
+func TestNegotiateVersion(t *testing.T) {
+	v2 := uint64p(2)
+	v5 := uint64p(5)
+
+	testCases := []struct {
+		name     string
+		local    statusOptions
+		remote   statusOptions
+		expected uint64
+	}{
+		{"both old peers with no version", statusOptions{}, statusOptions{}, baseProtocolVersion},
+		{"old local, new remote", statusOptions{}, statusOptions{Version: v5}, baseProtocolVersion},
+		{"new local, old remote", statusOptions{Version: v5}, statusOptions{}, baseProtocolVersion},
+		{"both new, picks the lower version", statusOptions{Version: v5}, statusOptions{Version: v2}, 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			negotiated, err := tc.local.Negotiate(tc.remote)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if negotiated.Version == nil || *negotiated.Version != tc.expected {
+				t.Fatalf("expected version %d, got %v", tc.expected, negotiated.Version)
+			}
+		})
+	}
+}
+
+func TestNegotiateCapabilities(t *testing.T) {
+	local := statusOptions{Capabilities: []string{"a", "b", "c"}}
+	remote := statusOptions{Capabilities: []string{"b", "c", "d"}}
+
+	negotiated, err := local.Negotiate(remote)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]bool{"b": true, "c": true}
+	if len(negotiated.Capabilities) != len(expected) {
+		t.Fatalf("expected %d capabilities, got %v", len(expected), negotiated.Capabilities)
+	}
+	for _, c := range negotiated.Capabilities {
+		if !expected[c] {
+			t.Fatalf("unexpected capability %s in negotiated result", c)
+		}
+	}
+}
+
+func TestNegotiateNoCommonCapabilities(t *testing.T) {
+	local := statusOptions{Capabilities: []string{"a"}}
+	remote := statusOptions{}
+
+	negotiated, err := local.Negotiate(remote)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(negotiated.Capabilities) != 0 {
+		t.Fatalf("expected no capabilities, got %v", negotiated.Capabilities)
+	}
+}
@@ -0,0 +1,136 @@
+package waku
+
+import "testing"
+
+func makeTopics(n int) []TopicType {
+	topics := make([]TopicType, n)
+	for i := range topics {
+		topics[i] = TopicType{byte(i), byte(i >> 8), byte(i >> 16), byte(i >> 24)}
+	}
+	return topics
+}
+
+func TestValidateRejectsOversizedPlainTopicInterest(t *testing.T) {
+	o := statusOptions{TopicInterest: makeTopics(maxPlainTopicInterest + 1)}
+	if err := o.Validate(); err == nil {
+		t.Fatal("expected an error for an oversized plain topic interest")
+	}
+}
+
+func TestValidateRejectsUnnegotiatedCompressedTopicInterest(t *testing.T) {
+	topics := makeTopics(maxPlainTopicInterest + 1)
+	encoded, err := EncodeTopicInterest(topics, []string{CapabilityCompressedTopicInterest})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(encoded.CompressedTopicInterest) == 0 {
+		t.Fatal("expected topics to be compressed")
+	}
+
+	// Without the capability in o.Capabilities, Validate must not accept
+	// CompressedTopicInterest. A peer claiming the capability for itself is
+	// only filtered out by Negotiate, which the NegotiateHandshake tests
+	// below cover.
+	if err := encoded.Validate(); err == nil {
+		t.Fatal("expected an error: capability was never negotiated")
+	}
+}
+
+func TestValidateAllowsOversizedCompressedTopicInterestOnceNegotiated(t *testing.T) {
+	topics := makeTopics(maxPlainTopicInterest + 1)
+	encoded, err := EncodeTopicInterest(topics, []string{CapabilityCompressedTopicInterest})
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded.Capabilities = []string{CapabilityCompressedTopicInterest}
+
+	local := statusOptions{Capabilities: []string{CapabilityCompressedTopicInterest}}
+	negotiated, err := encoded.Negotiate(local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := negotiated.Validate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestNegotiateHandshakeRejectsUnnegotiatedCompressedTopicInterest(t *testing.T) {
+	topics := makeTopics(maxPlainTopicInterest + 1)
+	remote, err := EncodeTopicInterest(topics, []string{CapabilityCompressedTopicInterest})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// remote claims the capability itself, but the local node never
+	// advertised it, so it must not be considered negotiated.
+	remote.Capabilities = []string{CapabilityCompressedTopicInterest}
+	local := statusOptions{}
+
+	if _, err := local.NegotiateHandshake(remote); err == nil {
+		t.Fatal("expected an error: capability was not mutually negotiated")
+	}
+}
+
+func TestNegotiateHandshakeAllowsMutuallyNegotiatedCompressedTopicInterest(t *testing.T) {
+	topics := makeTopics(maxPlainTopicInterest + 1)
+	remote, err := EncodeTopicInterest(topics, []string{CapabilityCompressedTopicInterest})
+	if err != nil {
+		t.Fatal(err)
+	}
+	remote.Capabilities = []string{CapabilityCompressedTopicInterest}
+	local := statusOptions{Capabilities: []string{CapabilityCompressedTopicInterest}}
+
+	negotiated, err := local.NegotiateHandshake(remote)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(negotiated.CompressedTopicInterest) == 0 {
+		t.Fatal("expected the negotiated options to keep the compressed topic interest")
+	}
+}
+
+func TestEncodeTopicInterestFallsBackWithoutCapability(t *testing.T) {
+	topics := makeTopics(maxPlainTopicInterest + 1)
+	encoded, err := EncodeTopicInterest(topics, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if encoded.CompressedTopicInterest != nil {
+		t.Fatal("expected plain topic interest when remote lacks the capability")
+	}
+	if len(encoded.TopicInterest) != len(topics) {
+		t.Fatalf("expected %d topics, got %d", len(topics), len(encoded.TopicInterest))
+	}
+}
+
+func TestDecodeTopicInterestRoundTrip(t *testing.T) {
+	topics := makeTopics(1500)
+	encoded, err := EncodeTopicInterest(topics, []string{CapabilityCompressedTopicInterest})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := DecodeTopicInterest(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != len(topics) {
+		t.Fatalf("expected %d topics, got %d", len(topics), len(decoded))
+	}
+	for i := range topics {
+		if decoded[i] != topics[i] {
+			t.Fatalf("topic %d mismatch: %v != %v", i, decoded[i], topics[i])
+		}
+	}
+}
+
+func TestDecodeTopicInterestPlain(t *testing.T) {
+	topics := makeTopics(10)
+	o := statusOptions{TopicInterest: topics}
+	decoded, err := DecodeTopicInterest(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != len(topics) {
+		t.Fatalf("expected %d topics, got %d", len(topics), len(decoded))
+	}
+}